@@ -20,65 +20,44 @@ package main
 
 import (
 	".."
+	"../render"
 	"fmt"
+	"image/color"
 	"math/rand"
 	"os"
-	"text/template"
 )
 
-const TEMPLATE = `<?xml version="1.0" ?>
-<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN"
-  "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
-<svg width="{{.Width}}px" height="{{.Height}}px" viewBox="0 0 {{.Width}} {{.Height}}"
-     xmlns="http://www.w3.org/2000/svg" version="1.1">
-  <title>{{.Title}}</title>
-  <desc>{{.Description}}</desc>
-  <!-- Edges -->
-  <g stroke="red" stroke-width="{{.StrokeWidth}}" fill="none">
-    {{range .Edges}}<path d="M{{.Start.X}},{{.Start.Y}} L{{.End.X}},{{.End.Y}}" />
-    {{end}}</g>
-  <!-- Vertices -->
-  <g fill="black" >
-    {{range .Vertices}}<circle cx="{{.X}}" cy="{{.Y}}" r="{{$.PointRadius}}" />
-    {{end}}</g>
-</svg>`
-
-type SVG struct {
-	Width       float64
-	Height      float64
-	Edges       voronoi.Edges
-	Vertices    voronoi.Vertices
-	Title       string
-	Description string
-	StrokeWidth float64
-	PointRadius float64
-}
-
 func main() {
 	pts := 600
+	width := 500.0
+	height := 500.0
 	vor := voronoi.Voronoi{}
-	svg := SVG{
-		Title:       "Voronoi diagram",
-		Description: "Edges and points",
-		Width:       500,
-		Height:      500,
-		StrokeWidth: 1,
-		PointRadius: 1,
-		Vertices:    make([]*voronoi.Point, pts),
-	}
+	vertices := make(voronoi.Vertices, pts)
 	rnd := rand.New(rand.NewSource(7584))
 	for i := 0; i < pts; i++ {
 		var (
-			x = rnd.Float64() * svg.Width
-			y = rnd.Float64() * svg.Height
+			x = rnd.Float64() * width
+			y = rnd.Float64() * height
 		)
 		str := fmt.Sprintf("Point at %v,%v\n", x, y)
 		os.Stderr.Write([]byte(str))
-		svg.Vertices[i] = voronoi.Pt(x, y)
+		vertices[i] = voronoi.Pt(x, y)
+	}
+	cells := vor.GetCells(&vertices, width, height)
+
+	svg := render.NewSVGRenderer(width, height)
+	svg.Title = "Voronoi diagram"
+	svg.Description = "Edges and points"
+	render.RenderCells(svg, cells, func(i int) color.Color {
+		return color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff}
+	})
+	for _, e := range vor.Edges {
+		svg.DrawEdge(e)
+	}
+	for _, p := range vertices {
+		svg.DrawSite(p)
 	}
-	svg.Edges = vor.GetEdges(&svg.Vertices, svg.Width, svg.Height)
-	tmpl := template.Must(template.New("svg").Parse(TEMPLATE))
-	if err := tmpl.Execute(os.Stdout, svg); err != nil {
+	if err := svg.Finish(os.Stdout); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
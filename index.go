@@ -0,0 +1,283 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voronoi
+
+import (
+	"math"
+	"sort"
+)
+
+// rtreeFanout is the maximum number of children packed under one internal
+// node of the bulk-loaded STR-tree.
+const rtreeFanout = 16
+
+// Rect is an axis-aligned bounding box, used both as the unit stored in the
+// SpatialIndex and as the query shape for EdgesIn.
+type Rect struct {
+	MinX float64
+	MinY float64
+	MaxX float64
+	MaxY float64
+}
+
+func rectContains(r Rect, p Point) bool {
+	return p.X >= r.MinX && p.X <= r.MaxX && p.Y >= r.MinY && p.Y <= r.MaxY
+}
+
+func rectsOverlap(a Rect, b Rect) bool {
+	return a.MinX <= b.MaxX && a.MaxX >= b.MinX && a.MinY <= b.MaxY && a.MaxY >= b.MinY
+}
+
+func unionRect(a Rect, b Rect) Rect {
+	return Rect{
+		MinX: math.Min(a.MinX, b.MinX),
+		MinY: math.Min(a.MinY, b.MinY),
+		MaxX: math.Max(a.MaxX, b.MaxX),
+		MaxY: math.Max(a.MaxY, b.MaxY),
+	}
+}
+
+func boundsOfPoints(pts []*Point) Rect {
+	r := Rect{MinX: pts[0].X, MaxX: pts[0].X, MinY: pts[0].Y, MaxY: pts[0].Y}
+	for _, p := range pts[1:] {
+		if p.X < r.MinX {
+			r.MinX = p.X
+		}
+		if p.X > r.MaxX {
+			r.MaxX = p.X
+		}
+		if p.Y < r.MinY {
+			r.MinY = p.Y
+		}
+		if p.Y > r.MaxY {
+			r.MaxY = p.Y
+		}
+	}
+	return r
+}
+
+// pointInPolygon reports whether p lies inside poly using the standard
+// even-odd ray casting test.
+func pointInPolygon(p Point, poly []*Point) bool {
+	inside := false
+	n := len(poly)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		pi, pj := poly[i], poly[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) {
+			x := pi.X + (p.Y-pi.Y)/(pj.Y-pi.Y)*(pj.X-pi.X)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// rtreeNode is a node of the bulk-loaded STR-tree. Leaves carry a Cell or
+// an Edge; internal nodes carry children and the union of their bounds.
+type rtreeNode struct {
+	bounds   Rect
+	cell     *Cell
+	edge     *Edge
+	children []*rtreeNode
+}
+
+func (n *rtreeNode) isLeaf() bool {
+	return n.cell != nil || n.edge != nil
+}
+
+// SpatialIndex is a pair of STR-trees over a Voronoi diagram's cell and
+// edge bounding boxes.
+type SpatialIndex struct {
+	root     *rtreeNode
+	edgeRoot *rtreeNode
+	cells    []*Cell
+}
+
+// Index bulk-loads a SpatialIndex from the most recent GetCells/GetEdges
+// call. GetCells must have been run first, or Locate and NearestSite will
+// have no cells to search.
+func (v *Voronoi) Index() *SpatialIndex {
+	idx := &SpatialIndex{cells: v.Cells}
+	leaves := make([]*rtreeNode, 0, len(v.Cells))
+	for _, c := range v.Cells {
+		if len(c.Polygon) == 0 {
+			continue
+		}
+		leaves = append(leaves, &rtreeNode{bounds: boundsOfPoints(c.Polygon), cell: c})
+	}
+	if len(leaves) > 0 {
+		idx.root = strPack(leaves)
+	}
+	edgeLeaves := make([]*rtreeNode, 0, len(v.Edges))
+	for _, e := range v.Edges {
+		pts := make([]*Point, 0, 2)
+		if e.Start != nil {
+			pts = append(pts, e.Start)
+		}
+		if e.End != nil {
+			pts = append(pts, e.End)
+		}
+		if len(pts) == 0 {
+			continue
+		}
+		edgeLeaves = append(edgeLeaves, &rtreeNode{bounds: boundsOfPoints(pts), edge: e})
+	}
+	if len(edgeLeaves) > 0 {
+		idx.edgeRoot = strPack(edgeLeaves)
+	}
+	return idx
+}
+
+// strPack bulk-loads leaves into an STR-tree by repeatedly packing the
+// current level into groups of rtreeFanout nodes until a single root
+// remains.
+func strPack(nodes []*rtreeNode) *rtreeNode {
+	for len(nodes) > 1 {
+		nodes = strPackLevel(nodes)
+	}
+	return nodes[0]
+}
+
+func strPackLevel(nodes []*rtreeNode) []*rtreeNode {
+	var (
+		n         = len(nodes)
+		leafCount = int(math.Ceil(float64(n) / float64(rtreeFanout)))
+		slices    = int(math.Ceil(math.Sqrt(float64(leafCount))))
+	)
+	if slices < 1 {
+		slices = 1
+	}
+	sliceSize := int(math.Ceil(float64(n) / float64(slices)))
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return centerX(nodes[i].bounds) < centerX(nodes[j].bounds)
+	})
+
+	packed := make([]*rtreeNode, 0, leafCount)
+	for s := 0; s < n; s += sliceSize {
+		e := s + sliceSize
+		if e > n {
+			e = n
+		}
+		slice := nodes[s:e]
+		sort.Slice(slice, func(i, j int) bool {
+			return centerY(slice[i].bounds) < centerY(slice[j].bounds)
+		})
+		for i := 0; i < len(slice); i += rtreeFanout {
+			j := i + rtreeFanout
+			if j > len(slice) {
+				j = len(slice)
+			}
+			group := slice[i:j]
+			bounds := group[0].bounds
+			for _, g := range group[1:] {
+				bounds = unionRect(bounds, g.bounds)
+			}
+			packed = append(packed, &rtreeNode{bounds: bounds, children: group})
+		}
+	}
+	return packed
+}
+
+func centerX(r Rect) float64 {
+	return (r.MinX + r.MaxX) / 2
+}
+
+func centerY(r Rect) float64 {
+	return (r.MinY + r.MaxY) / 2
+}
+
+// Locate descends the tree to the cells whose bounds contain p and returns
+// the first whose polygon actually contains p, or nil if p falls outside
+// every cell.
+func (idx *SpatialIndex) Locate(p Point) *Cell {
+	if idx.root == nil {
+		return nil
+	}
+	return locate(idx.root, p)
+}
+
+func locate(n *rtreeNode, p Point) *Cell {
+	if !rectContains(n.bounds, p) {
+		return nil
+	}
+	if n.isLeaf() {
+		if pointInPolygon(p, n.cell.Polygon) {
+			return n.cell
+		}
+		return nil
+	}
+	for _, c := range n.children {
+		if found := locate(c, p); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// EdgesIn descends the edge STR-tree and returns every edge whose bounding
+// box overlaps rect.
+func (idx *SpatialIndex) EdgesIn(rect Rect) []*Edge {
+	if idx.edgeRoot == nil {
+		return nil
+	}
+	out := make(Edges, 0)
+	collectEdgesIn(idx.edgeRoot, rect, &out)
+	return out
+}
+
+func collectEdgesIn(n *rtreeNode, rect Rect, out *Edges) {
+	if !rectsOverlap(n.bounds, rect) {
+		return
+	}
+	if n.isLeaf() {
+		*out = append(*out, n.edge)
+		return
+	}
+	for _, c := range n.children {
+		collectEdgesIn(c, rect, out)
+	}
+}
+
+// NearestSite returns the k sites closest to p, nearest first, measured by
+// distance to the site itself. If there are fewer than k cells, it returns
+// all of them. Unlike
+// Locate and EdgesIn, this does not descend the tree: it is a plain O(N log
+// N) sort over every cell's site.
+func (idx *SpatialIndex) NearestSite(p Point, k int) []*Point {
+	if k < 0 {
+		k = 0
+	}
+	type candidate struct {
+		site *Point
+		dsq  float64
+	}
+	candidates := make([]candidate, 0, len(idx.cells))
+	for _, c := range idx.cells {
+		dx := c.Site.X - p.X
+		dy := c.Site.Y - p.Y
+		candidates = append(candidates, candidate{site: c.Site, dsq: dx*dx + dy*dy})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dsq < candidates[j].dsq })
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	sites := make([]*Point, k)
+	for i := 0; i < k; i++ {
+		sites[i] = candidates[i].site
+	}
+	return sites
+}
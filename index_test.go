@@ -0,0 +1,97 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voronoi
+
+import (
+	"testing"
+)
+
+// TestIndexLocate depends on GetCells returning a complete, non-degenerate
+// cell for every site (see TestGetCellsTileBoundingBox in voronoi_test.go) —
+// an index built over a dropped cell can never locate that site's own
+// point.
+func TestIndexLocate(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(8, 8),
+		Pt(8, 2),
+	}
+	v.GetCells(ver, 10, 10)
+	idx := v.Index()
+
+	for _, site := range *ver {
+		cell := idx.Locate(*site)
+		if cell == nil || cell.Site != site {
+			t.Fatalf("Locate(%v) did not return that site's own cell", site)
+		}
+	}
+}
+
+// TestIndexNearestSite shares TestIndexLocate's dependency on GetCells
+// producing one cell per site: idx.cells comes straight from v.Cells, so a
+// dropped cell is a missing candidate, not just a missing lookup result.
+func TestIndexNearestSite(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(8, 8),
+		Pt(8, 2),
+	}
+	v.GetCells(ver, 10, 10)
+	idx := v.Index()
+
+	nearest := idx.NearestSite(Point{X: 8, Y: 3}, 2)
+	if len(nearest) != 2 {
+		t.Fatalf("Wanted 2 nearest sites, got %v", len(nearest))
+	}
+	if nearest[0] != (*ver)[2] {
+		t.Fatalf("Wanted (8,2) to be nearest to (8,3), got %v", nearest[0])
+	}
+}
+
+// TestIndexNearestSiteNegativeK guards against a negative k reaching
+// make([]*Point, k), which panics.
+func TestIndexNearestSiteNegativeK(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(8, 8),
+		Pt(8, 2),
+	}
+	v.GetCells(ver, 10, 10)
+	idx := v.Index()
+
+	nearest := idx.NearestSite(Point{X: 8, Y: 3}, -1)
+	if len(nearest) != 0 {
+		t.Fatalf("Wanted 0 nearest sites for k=-1, got %v", len(nearest))
+	}
+}
+
+func TestIndexEdgesIn(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(8, 8),
+		Pt(8, 2),
+	}
+	v.GetCells(ver, 10, 10)
+	idx := v.Index()
+
+	edges := idx.EdgesIn(Rect{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10})
+	if len(edges) != len(v.Edges) {
+		t.Fatalf("Wanted %v edges overlapping the full box, got %v", len(v.Edges), len(edges))
+	}
+}
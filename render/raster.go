@@ -0,0 +1,169 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	".."
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+)
+
+// RasterRenderer is a Renderer that paints onto an in-memory image.RGBA
+// using only the standard library (a Bresenham line rasterizer and a
+// scanline polygon fill), and writes it out as a PNG on Finish.
+type RasterRenderer struct {
+	Width  int
+	Height int
+	img    *image.RGBA
+}
+
+// NewRasterRenderer returns a RasterRenderer for a width x height canvas.
+func NewRasterRenderer(width int, height int) *RasterRenderer {
+	return &RasterRenderer{
+		Width:  width,
+		Height: height,
+		img:    image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+}
+
+// Image returns the canvas drawn to so far, without encoding it.
+func (r *RasterRenderer) Image() image.Image {
+	return r.img
+}
+
+func (r *RasterRenderer) DrawEdge(e *voronoi.Edge) {
+	if e.Start == nil || e.End == nil {
+		return
+	}
+	drawLine(r.img, e.Start.X, e.Start.Y, e.End.X, e.End.Y, color.RGBA{R: 0xff, A: 0xff})
+}
+
+func (r *RasterRenderer) DrawSite(p *voronoi.Point) {
+	const radius = 1
+	fillPolygon(r.img, circlePoints(p.X, p.Y, radius), color.Black)
+}
+
+func (r *RasterRenderer) DrawCell(cell *voronoi.Cell, fill color.Color) {
+	if len(cell.Polygon) == 0 {
+		return
+	}
+	points := make([]voronoi.Point, len(cell.Polygon))
+	for i, p := range cell.Polygon {
+		points[i] = *p
+	}
+	fillPolygon(r.img, points, fill)
+}
+
+func (r *RasterRenderer) Finish(w io.Writer) error {
+	return png.Encode(w, r.img)
+}
+
+// drawLine rasterizes the segment (x0,y0)-(x1,y1) onto img with Bresenham's
+// algorithm.
+func drawLine(img *image.RGBA, x0 float64, y0 float64, x1 float64, y1 float64, c color.Color) {
+	var (
+		ix0, iy0 = int(math.Round(x0)), int(math.Round(y0))
+		ix1, iy1 = int(math.Round(x1)), int(math.Round(y1))
+		dx       = int(math.Abs(float64(ix1 - ix0)))
+		dy       = -int(math.Abs(float64(iy1 - iy0)))
+		sx       = 1
+		sy       = 1
+	)
+	if ix0 > ix1 {
+		sx = -1
+	}
+	if iy0 > iy1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		img.Set(ix0, iy0, c)
+		if ix0 == ix1 && iy0 == iy1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			ix0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			iy0 += sy
+		}
+	}
+}
+
+// circlePoints approximates a circle of the given radius centered at
+// (x, y) as a polygon, for reuse with fillPolygon.
+func circlePoints(x float64, y float64, radius float64) []voronoi.Point {
+	const segments = 12
+	points := make([]voronoi.Point, segments)
+	for i := 0; i < segments; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(segments)
+		points[i] = voronoi.Point{X: x + radius*math.Cos(theta), Y: y + radius*math.Sin(theta)}
+	}
+	return points
+}
+
+// fillPolygon fills poly onto img with an even-odd scanline rule, one row
+// of pixels at a time.
+func fillPolygon(img *image.RGBA, poly []voronoi.Point, c color.Color) {
+	if len(poly) < 3 {
+		return
+	}
+	var minY, maxY = poly[0].Y, poly[0].Y
+	for _, p := range poly[1:] {
+		if p.Y < minY {
+			minY = p.Y
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+	bounds := img.Bounds()
+	startY := int(math.Floor(minY))
+	if startY < bounds.Min.Y {
+		startY = bounds.Min.Y
+	}
+	endY := int(math.Ceil(maxY))
+	if endY > bounds.Max.Y {
+		endY = bounds.Max.Y
+	}
+	n := len(poly)
+	for y := startY; y < endY; y++ {
+		fy := float64(y) + 0.5
+		xs := make([]float64, 0, 4)
+		for i := 0; i < n; i++ {
+			a, b := poly[i], poly[(i+1)%n]
+			if (a.Y <= fy) == (b.Y <= fy) {
+				continue
+			}
+			t := (fy - a.Y) / (b.Y - a.Y)
+			xs = append(xs, a.X+t*(b.X-a.X))
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			x0 := int(math.Round(xs[i]))
+			x1 := int(math.Round(xs[i+1]))
+			for x := x0; x < x1; x++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
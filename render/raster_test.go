@@ -0,0 +1,53 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	".."
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRasterRendererFinish(t *testing.T) {
+	r := NewRasterRenderer(10, 10)
+	r.DrawCell(&voronoi.Cell{Polygon: []*voronoi.Point{
+		voronoi.Pt(2, 2),
+		voronoi.Pt(8, 2),
+		voronoi.Pt(8, 8),
+		voronoi.Pt(2, 8),
+	}}, color.RGBA{R: 0xff, A: 0xff})
+	r.DrawEdge(voronoi.Ed(0, 5, 9, 5))
+	r.DrawSite(voronoi.Pt(5, 5))
+
+	var buf bytes.Buffer
+	if err := r.Finish(&buf); err != nil {
+		t.Fatalf("Finish returned error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Finish wrote an undecodable PNG: %v", err)
+	}
+	if got := img.Bounds().Dx(); got != 10 {
+		t.Fatalf("Wanted width 10, got %v", got)
+	}
+	if red, _, _, a := img.At(5, 3).RGBA(); red == 0 || a == 0 {
+		t.Fatalf("Cell fill was not rendered at (5,3): rgba=%v,_,_,%v", red, a)
+	}
+	if _, _, _, a := img.At(0, 0).RGBA(); a != 0 {
+		t.Fatalf("Untouched pixel (0,0) should be transparent, got alpha %v", a)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Reusable rendering backends for Voronoi diagrams, so callers don't have to
+// re-implement SVG markup or scanline fill to turn a diagram into an image.
+package render
+
+import (
+	".."
+	"image/color"
+	"io"
+)
+
+// Renderer draws the pieces of a Voronoi diagram and flushes them to an
+// output format. Implementations are responsible for their own styling
+// defaults; Draw* may be called in any order and any number of times before
+// Finish.
+type Renderer interface {
+	DrawEdge(e *voronoi.Edge)
+	DrawSite(p *voronoi.Point)
+	DrawCell(cell *voronoi.Cell, fill color.Color)
+	Finish(w io.Writer) error
+}
+
+// RenderCells fills every cell through r, using colorFunc to pick a color
+// for each cell by its index in cells.
+func RenderCells(r Renderer, cells []*voronoi.Cell, colorFunc func(i int) color.Color) {
+	for i, cell := range cells {
+		r.DrawCell(cell, colorFunc(i))
+	}
+}
+
+// hexColor renders c as a CSS-style "#rrggbb" string for use in SVG
+// attributes.
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	const hex = "0123456789abcdef"
+	buf := [7]byte{'#'}
+	vals := [3]uint32{r >> 8, g >> 8, b >> 8}
+	for i, v := range vals {
+		buf[1+i*2] = hex[(v>>4)&0xf]
+		buf[2+i*2] = hex[v&0xf]
+	}
+	return string(buf[:])
+}
@@ -0,0 +1,122 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	".."
+	"image/color"
+	"io"
+	"text/template"
+)
+
+// svgTemplate renders the SVG body. It is executed with text/template, not
+// html/template: an SVG document is XML, and html/template's HTML5 tokenizer
+// mangles the leading `<?xml ?>` processing instruction and strips the
+// `<!-- -->` comments below, producing invalid XML. The only untrusted
+// fields it interpolates, Title and Description, are escaped by hand before
+// the template runs.
+var svgTemplate = template.Must(template.New("svg").Parse(`<?xml version="1.0" ?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN"
+  "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
+<svg width="{{.Width}}px" height="{{.Height}}px" viewBox="0 0 {{.Width}} {{.Height}}"
+     xmlns="http://www.w3.org/2000/svg" version="1.1">
+  <title>{{.Title}}</title>
+  <desc>{{.Description}}</desc>
+  <!-- Cells -->
+  <g stroke="none">
+    {{range .Cells}}<polygon fill="{{.Fill}}" points="{{range .Points}}{{.X}},{{.Y}} {{end}}" />
+    {{end}}</g>
+  <!-- Edges -->
+  <g stroke="red" stroke-width="{{.StrokeWidth}}" fill="none">
+    {{range .Edges}}<path d="M{{.X1}},{{.Y1}} L{{.X2}},{{.Y2}}" />
+    {{end}}</g>
+  <!-- Sites -->
+  <g fill="black">
+    {{range .Sites}}<circle cx="{{.X}}" cy="{{.Y}}" r="{{$.PointRadius}}" />
+    {{end}}</g>
+</svg>`))
+
+type svgEdge struct {
+	X1 float64
+	Y1 float64
+	X2 float64
+	Y2 float64
+}
+
+type svgSite struct {
+	X float64
+	Y float64
+}
+
+type svgPoint struct {
+	X float64
+	Y float64
+}
+
+type svgCell struct {
+	Points []svgPoint
+	Fill   string
+}
+
+// SVGRenderer is a Renderer that accumulates drawing calls and, on Finish,
+// writes them out as a single SVG document.
+type SVGRenderer struct {
+	Width       float64
+	Height      float64
+	Title       string
+	Description string
+	StrokeWidth float64
+	PointRadius float64
+	Edges       []svgEdge
+	Sites       []svgSite
+	Cells       []svgCell
+}
+
+// NewSVGRenderer returns an SVGRenderer for a document width x height, with
+// the same stroke width and point radius defaults basic.go used.
+func NewSVGRenderer(width float64, height float64) *SVGRenderer {
+	return &SVGRenderer{
+		Width:       width,
+		Height:      height,
+		StrokeWidth: 1,
+		PointRadius: 1,
+	}
+}
+
+func (r *SVGRenderer) DrawEdge(e *voronoi.Edge) {
+	if e.Start == nil || e.End == nil {
+		return
+	}
+	r.Edges = append(r.Edges, svgEdge{X1: e.Start.X, Y1: e.Start.Y, X2: e.End.X, Y2: e.End.Y})
+}
+
+func (r *SVGRenderer) DrawSite(p *voronoi.Point) {
+	r.Sites = append(r.Sites, svgSite{X: p.X, Y: p.Y})
+}
+
+func (r *SVGRenderer) DrawCell(cell *voronoi.Cell, fill color.Color) {
+	points := make([]svgPoint, len(cell.Polygon))
+	for i, p := range cell.Polygon {
+		points[i] = svgPoint{X: p.X, Y: p.Y}
+	}
+	r.Cells = append(r.Cells, svgCell{Points: points, Fill: hexColor(fill)})
+}
+
+func (r *SVGRenderer) Finish(w io.Writer) error {
+	escaped := *r
+	escaped.Title = template.HTMLEscapeString(r.Title)
+	escaped.Description = template.HTMLEscapeString(r.Description)
+	return svgTemplate.Execute(w, &escaped)
+}
@@ -0,0 +1,61 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	".."
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSVGRendererFinish(t *testing.T) {
+	svg := NewSVGRenderer(10, 10)
+	svg.Title = "<Test>"
+	svg.DrawSite(voronoi.Pt(1, 2))
+	svg.DrawEdge(voronoi.Ed(0, 0, 5, 5))
+	svg.DrawCell(&voronoi.Cell{Polygon: []*voronoi.Point{
+		voronoi.Pt(0, 0),
+		voronoi.Pt(1, 0),
+		voronoi.Pt(1, 1),
+	}}, color.RGBA{R: 0xff, A: 0xff})
+
+	var buf bytes.Buffer
+	if err := svg.Finish(&buf); err != nil {
+		t.Fatalf("Finish returned error: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<Test>") {
+		t.Fatalf("Title was not escaped: %v", out)
+	}
+	if !strings.Contains(out, "cx=\"1\" cy=\"2\"") {
+		t.Fatalf("Site was not rendered: %v", out)
+	}
+	if !strings.Contains(out, "fill=\"#ff0000\"") {
+		t.Fatalf("Cell fill was not rendered: %v", out)
+	}
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatalf("Finish did not produce well-formed XML: %v\n%s", err, out)
+		}
+	}
+}
@@ -71,6 +71,24 @@ func NewEdge(s *Point, a *Point, b *Point) *Edge {
 
 type Edges []*Edge
 
+// Triangle is a face of the Delaunay triangulation, the dual of a Voronoi
+// vertex where three cells meet.
+type Triangle struct {
+	A *Point
+	B *Point
+	C *Point
+}
+
+type Triangles []*Triangle
+
+// Cell is the closed polygon surrounding a single site, clipped to the
+// diagram's bounding box.
+type Cell struct {
+	Site      *Point
+	Neighbors []*Point
+	Polygon   []*Point
+}
+
 type Event struct {
 	Point   *Point
 	IsPlace bool
@@ -275,6 +293,8 @@ type Voronoi struct {
 	Height   float64
 	Root     *Parabola
 	Y        float64
+	Delaunay Triangles
+	Cells    []*Cell
 	del      EventList
 	points   Vertices
 	queue    EventQueue
@@ -286,6 +306,7 @@ func (v *Voronoi) GetEdges(places *Vertices, w float64, h float64) Edges {
 	v.Height = h
 	v.Root = nil
 	v.Edges = make(Edges, 0, 0)
+	v.Delaunay = make(Triangles, 0, 0)
 	v.points = make(Vertices, 0, 0)
 
 	v.queue = make(EventQueue, 0, len(*places)+1)
@@ -320,6 +341,158 @@ func (v *Voronoi) GetEdges(places *Vertices, w float64, h float64) Edges {
 	return v.Edges
 }
 
+// GetTriangulation runs Fortune's algorithm and returns the dual Delaunay
+// triangulation of places instead of the Voronoi edges.
+func (v *Voronoi) GetTriangulation(places *Vertices, w float64, h float64) (Edges, Triangles) {
+	v.GetEdges(places, w, h)
+	delaunay := make(Edges, 0, len(v.Edges))
+	for _, e := range v.Edges {
+		if e.Left == nil || e.Right == nil {
+			continue
+		}
+		delaunay = append(delaunay, Ed(e.Left.X, e.Left.Y, e.Right.X, e.Right.Y))
+	}
+	return delaunay, v.Delaunay
+}
+
+// clipHalfPlane Sutherland-Hodgman clips poly against a single half-plane.
+func clipHalfPlane(poly []*Point, inside func(*Point) bool, intersect func(*Point, *Point) *Point) []*Point {
+	if len(poly) == 0 {
+		return poly
+	}
+	var (
+		out    = make([]*Point, 0, len(poly))
+		prev   = poly[len(poly)-1]
+		prevIn = inside(prev)
+	)
+	for _, cur := range poly {
+		curIn := inside(cur)
+		if curIn {
+			if !prevIn {
+				out = append(out, intersect(prev, cur))
+			}
+			out = append(out, cur)
+		} else if prevIn {
+			out = append(out, intersect(prev, cur))
+		}
+		prev = cur
+		prevIn = curIn
+	}
+	return out
+}
+
+// GetCells runs Fortune's algorithm and builds one closed, CCW-ordered
+// polygon per site by clipping the w x h bounding box against the
+// perpendicular-bisector half-plane of every other site.
+func (v *Voronoi) GetCells(places *Vertices, w float64, h float64) []*Cell {
+	v.GetEdges(places, w, h)
+	cells := make([]*Cell, 0, len(*places))
+	for _, site := range *places {
+		var (
+			neighbors = make([]*Point, 0)
+			poly      = []*Point{Pt(0, 0), Pt(w, 0), Pt(w, h), Pt(0, h)}
+		)
+		for _, other := range *places {
+			if other == site {
+				continue
+			}
+			before := poly
+			poly = clipToBisector(poly, site, other)
+			if !samePolygon(before, poly) {
+				neighbors = append(neighbors, other)
+			}
+		}
+		cells = append(cells, &Cell{
+			Site:      site,
+			Neighbors: neighbors,
+			Polygon:   poly,
+		})
+	}
+	v.Cells = cells
+	return cells
+}
+
+// clipToBisector clips poly against the perpendicular bisector of site and
+// other, keeping the half closer to site.
+func clipToBisector(poly []*Point, site *Point, other *Point) []*Point {
+	var (
+		mid  = Pt((site.X+other.X)/2, (site.Y+other.Y)/2)
+		n    = Pt(other.X-site.X, other.Y-site.Y)
+		side = func(p *Point) float64 { return (p.X-mid.X)*n.X + (p.Y-mid.Y)*n.Y }
+	)
+	return clipHalfPlane(poly, func(p *Point) bool { return side(p) <= 0 },
+		func(a *Point, b *Point) *Point {
+			t := side(a) / (side(a) - side(b))
+			return Pt(a.X+t*(b.X-a.X), a.Y+t*(b.Y-a.Y))
+		})
+}
+
+// samePolygon reports whether a and b hold the same vertices in the same
+// order.
+func samePolygon(a []*Point, b []*Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].X != b[i].X || a[i].Y != b[i].Y {
+			return false
+		}
+	}
+	return true
+}
+
+// polygonArea returns the signed area of poly using the shoelace formula.
+func polygonArea(poly []*Point) float64 {
+	var area float64
+	n := len(poly)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		area += poly[i].X*poly[j].Y - poly[j].X*poly[i].Y
+	}
+	return area / 2.0
+}
+
+// polygonCentroid returns the area-weighted centroid of poly, given its
+// signed area as computed by polygonArea.
+func polygonCentroid(poly []*Point, area float64) *Point {
+	var cx, cy float64
+	n := len(poly)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		cross := poly[i].X*poly[j].Y - poly[j].X*poly[i].Y
+		cx += (poly[i].X + poly[j].X) * cross
+		cy += (poly[i].Y + poly[j].Y) * cross
+	}
+	f := 1.0 / (6.0 * area)
+	return Pt(cx*f, cy*f)
+}
+
+// Relax runs Lloyd's algorithm on places for the given number of
+// iterations, moving each site to its cell's centroid. Sites whose cell
+// degenerates (zero area) are left in place for that iteration.
+func (v *Voronoi) Relax(places *Vertices, w float64, h float64, iterations int) Vertices {
+	current := make(Vertices, len(*places))
+	copy(current, *places)
+	for i := 0; i < iterations; i++ {
+		next := make(Vertices, len(current))
+		copy(next, current)
+		for _, c := range v.GetCells(&current, w, h) {
+			area := polygonArea(c.Polygon)
+			if math.Abs(area) < 1e-9 {
+				continue
+			}
+			for j, p := range current {
+				if p == c.Site {
+					next[j] = polygonCentroid(c.Polygon, area)
+					break
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
 func (v *Voronoi) insertParabola(p *Point) {
 	if v.Root == nil {
 		v.Root = NewLeafParabola(p)
@@ -412,6 +585,7 @@ func (v *Voronoi) removeParabola(e *Event) {
 
 	higher.Edge = NewEdge(p, p0.Site, p2.Site)
 	v.Edges = append(v.Edges, higher.Edge)
+	v.Delaunay = append(v.Delaunay, &Triangle{A: p0.Site, B: p1.Site, C: p2.Site})
 
 	gparent := p1.Parent.Parent
 	if p1.Parent.Left() == p1 {
@@ -521,6 +695,11 @@ func (v *Voronoi) getY(p *Point, x float64) float64 {
 	return a1*x*x + b1*x + c1
 }
 
+// finishEdge still extends unbounded rays to x = 0 or x = Width, or to
+// Start.X -/+ 10 when that lies farther out, rather than clipping to the
+// bounding box; GetCells no longer relies on this (it rebuilds cells by
+// half-plane clipping instead), but the Voronoi edges returned by GetEdges,
+// and left in v.Edges by GetTriangulation and GetCells, are extended this way.
 func (v *Voronoi) finishEdge(n *Parabola) {
 	if n.IsLeaf {
 		return
@@ -16,6 +16,7 @@ package voronoi
 
 import (
 	"container/heap"
+	"math"
 	"testing"
 )
 
@@ -117,6 +118,110 @@ func TestGetEdges(t *testing.T) {
 	(*Testing)(t).CompareEdges(edges, valid)
 }
 
+func TestGetTriangulation(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(2, 3),
+		Pt(5, 1),
+	}
+	delaunay, triangles := v.GetTriangulation(ver, 10, 10)
+	if len(delaunay) != 3 {
+		t.Fatalf("Wanted 3 Delaunay edges, got %v", len(delaunay))
+	}
+	if len(triangles) != 1 {
+		t.Fatalf("Wanted 1 Delaunay triangle, got %v", len(triangles))
+	}
+	tri := triangles[0]
+	for _, s := range []*Point{tri.A, tri.B, tri.C} {
+		found := false
+		for _, p := range *ver {
+			if p == s {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Triangle vertex %v is not one of the input places", s)
+		}
+	}
+}
+
+func TestGetCells(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(2, 3),
+		Pt(5, 1),
+	}
+	cells := v.GetCells(ver, 10, 10)
+	if len(cells) != len(*ver) {
+		t.Fatalf("Wanted %v cells, got %v", len(*ver), len(cells))
+	}
+	for _, c := range cells {
+		if len(c.Neighbors) == 0 {
+			t.Fatalf("Cell for site %v has no neighbors", c.Site)
+		}
+		if len(c.Polygon) < 3 {
+			t.Fatalf("Cell for site %v has degenerate polygon %v", c.Site, c.Polygon)
+		}
+		for _, p := range c.Polygon {
+			if p.X < -0.0001 || p.X > 10.0001 || p.Y < -0.0001 || p.Y > 10.0001 {
+				t.Fatalf("Cell for site %v has polygon point %v outside bounds", c.Site, p)
+			}
+		}
+	}
+}
+
+// TestGetCellsTileBoundingBox checks that the cells returned by GetCells
+// actually partition the w x h bounding box, rather than just being
+// individually well-formed: their areas should sum to w*h, and every site
+// should fall inside its own cell. Both regressed to an ad-hoc "raw edge
+// points" polygon that left gaps at the border and silently dropped sites
+// with too few incident edges.
+func TestGetCellsTileBoundingBox(t *testing.T) {
+	fixtures := []*Vertices{
+		{Pt(1, 2), Pt(2, 3), Pt(5, 1)},
+		{Pt(1, 2), Pt(8, 8), Pt(8, 2)},
+	}
+	for _, ver := range fixtures {
+		v := Voronoi{}
+		cells := v.GetCells(ver, 10, 10)
+		if len(cells) != len(*ver) {
+			t.Fatalf("Wanted %v cells, got %v", len(*ver), len(cells))
+		}
+		var total float64
+		for _, c := range cells {
+			area := polygonArea(c.Polygon)
+			total += area
+			if !pointInPolygon(*c.Site, c.Polygon) {
+				t.Fatalf("Site %v is not inside its own cell %v", c.Site, c.Polygon)
+			}
+		}
+		if math.Abs(total-100) > 1e-6 {
+			t.Fatalf("Wanted cell areas to sum to 100, got %v", total)
+		}
+	}
+}
+
+func TestRelax(t *testing.T) {
+	v := Voronoi{}
+	ver := &Vertices{
+		Pt(1, 2),
+		Pt(2, 3),
+		Pt(5, 1),
+		Pt(8, 8),
+	}
+	relaxed := v.Relax(ver, 10, 10, 2)
+	if len(relaxed) != len(*ver) {
+		t.Fatalf("Wanted %v sites, got %v", len(*ver), len(relaxed))
+	}
+	for _, p := range relaxed {
+		if p.X < 0 || p.X > 10 || p.Y < 0 || p.Y > 10 {
+			t.Fatalf("Relaxed site %v left the bounding box", p)
+		}
+	}
+}
+
 func TestGetVerticalEdges(t *testing.T) {
 	v := Voronoi{}
 	ver := &Vertices{